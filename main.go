@@ -1,13 +1,25 @@
 package main
 
 import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"image"
 	"image/color"
 	"log"
 	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/vector"
+	"gopkg.in/yaml.v3"
 )
 
 const (
@@ -32,69 +44,328 @@ type CelestialBodyDetails struct {
 }
 
 type CelestialBody struct {
+	name              string               // Name of the body, as given in the system file
 	parent            *CelestialBody       // Pointer to the parent entity, nil if no parent
 	orbit             *Orbit               // Orbital parameters, nil if not in an orbit
-	last_update_time  int64                // Timestamp of the last position update
-	position_on_orbit float64              // Current position in the orbit as a fraction of the period
+	last_update_time  int64                // Timestamp (UnixNano) of the last position update
+	mean_anomaly      float64              // Current mean anomaly M, radians, advances linearly with time
+	position_on_orbit float64              // Current position in the orbit as a fraction of a full revolution, derived from true anomaly
 	mass              float64              // Mass of the celestial body
 	gravity           float64              // Gravitational pull force
+	radius            float64              // Radius used for drawing and picking
+	color             color.RGBA           // Color used for drawing
 	details           CelestialBodyDetails // The details of the celestial body
 }
 
 type Orbit struct {
-	inclination float64 // Inclination of the orbit
-	apoapsis    float64 // Apoapsis
-	periapsis   float64 // Periapsis
-	period      float64 // Orbital period
+	inclination                 float64 // Inclination of the orbit
+	apoapsis                    float64 // Apoapsis
+	periapsis                   float64 // Periapsis
+	period                      float64 // Orbital period
+	argument_of_periapsis       float64 // Argument of periapsis (omega)
+	longitude_of_ascending_node float64 // Longitude of ascending node (Omega)
+}
+
+// Pickable is implemented by anything that can be hit-tested against a
+// screen-space point, e.g. for mouse hover/click handling.
+type Pickable interface {
+	Bounds(camera *Camera) image.Rectangle
+	ScreenPosition(camera *Camera) Vec2
+	Radius(camera *Camera) float64
+}
+
+// Camera maps world-space positions (centered on the root of the loaded
+// system) to screen-space pixels, and supports panning, zooming, and
+// smoothly following a focused body.
+type Camera struct {
+	Position  Vec2
+	Zoom      float64
+	Target    *CelestialBody
+	ViewPitch float64 // Tilt of the whole system, radians; set from Game.view_pitch each frame
+
+	dragging  bool
+	lastDragX int
+	lastDragY int
+}
+
+// NewCamera returns a Camera centered on the world origin at 1x zoom.
+func NewCamera() *Camera {
+	return &Camera{Zoom: 1}
+}
+
+// WorldToScreen converts a world-space position to screen-space pixels.
+func (c *Camera) WorldToScreen(pos Vec2) (float32, float32) {
+	x := (pos.X-c.Position.X)*c.Zoom + screenWidth/2
+	y := (pos.Y-c.Position.Y)*c.Zoom + screenHeight/2
+	return float32(x), float32(y)
+}
+
+// Update handles zoom, pan, and the smooth follow of c.Target.
+func (c *Camera) Update() {
+	if _, wheelY := ebiten.Wheel(); wheelY != 0 {
+		c.Zoom *= math.Pow(1.1, wheelY)
+		c.Zoom = math.Max(0.01, math.Min(c.Zoom, 50))
+	}
+
+	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonMiddle) {
+		cx, cy := ebiten.CursorPosition()
+		if c.dragging {
+			c.Position.X -= float64(cx-c.lastDragX) / c.Zoom
+			c.Position.Y -= float64(cy-c.lastDragY) / c.Zoom
+		}
+		c.lastDragX, c.lastDragY = cx, cy
+		c.dragging = true
+	} else {
+		c.dragging = false
+	}
+
+	if c.Target != nil {
+		target := c.Target.WorldPosition(c.ViewPitch)
+		c.Position.X += (target.X - c.Position.X) * 0.15
+		c.Position.Y += (target.Y - c.Position.Y) * 0.15
+	}
 }
 
 type Game struct {
-	sun          CelestialBody
-	earth        CelestialBody
-	moon         CelestialBody
+	bodies       map[string]*CelestialBody // All bodies in the loaded system, keyed by name
+	camera       *Camera
 	focused_body *CelestialBody
+	hovered_body *CelestialBody
+	time_scale   float64
+	view_pitch   float64 // Tilt of the whole system, radians; controlled with up/down arrows
+
+	refresh_rate int           // Hz the background scheduler rate-limits redraws to
+	drawFrame    chan struct{} // signaled when Update detects something worth redrawing
+	dirty        bool          // set by requestDrawIfNeeded, cleared once Draw has actually redrawn
+	has_orbiters bool          // true if any loaded body orbits something, set once at startup
+
+	last_cursor_x     int
+	last_cursor_y     int
+	last_camera       Camera
+	last_screen_pos   map[string]Vec2
+	last_pressed_keys []ebiten.Key
 }
 
 func (g *Game) Update() error {
-	g.sun.Update()
-	g.earth.Update()
-	g.moon.Update()
-
-	o := g.earth.orbit
-	a := (o.apoapsis + o.periapsis) / 2
-	e := (a - o.periapsis) / a
+	now := time.Now().UnixNano()
+	for _, cb := range g.bodies {
+		cb.Update(now, g.time_scale)
+	}
 
-	theta := g.earth.parent.position_on_orbit * 2 * math.Pi
-	x, y := TrueAnomalyToPosition(a, e, o.inclination, theta)
-	ax, ay := g.earth.GetPosition()
 	cursor_x, cursor_y := ebiten.CursorPosition()
-	dist_x, dist_y := ax+x-float64(cursor_x), ay+y-float64(cursor_y)
+	g.hovered_body = g.PickAt(cursor_x, cursor_y)
 
-	log.Printf("%f %f\n", dist_x, dist_y)
-	// 5 should be the the radius of the planet. or similar
-	if math.Sqrt(dist_x*dist_x-dist_y*dist_y) < 5 {
-		// we can do on-hover logic here
-		// to draw cool shit when we hover the planet
-		if ebiten.IsKeyPressed(ebiten.KeyS) {
-			g.focused_body = &g.earth
-		}
+	if g.hovered_body != nil && ebiten.IsKeyPressed(ebiten.KeyS) {
+		g.focused_body = g.hovered_body
 	}
 
+	const viewPitchStep = 0.02
+	if ebiten.IsKeyPressed(ebiten.KeyUp) {
+		g.view_pitch -= viewPitchStep
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyDown) {
+		g.view_pitch += viewPitchStep
+	}
+	g.view_pitch = math.Max(-math.Pi/2, math.Min(g.view_pitch, math.Pi/2))
+
+	g.camera.Target = g.focused_body
+	g.camera.ViewPitch = g.view_pitch
+	g.camera.Update()
+
+	g.requestDrawIfNeeded(cursor_x, cursor_y)
+
 	return nil
 }
 
-func TrueAnomalyToPosition(a, e, inclination, theta float64) (float64, float64) {
+// requestDrawIfNeeded compares this frame's state against the last frame's
+// and, if anything that would actually change a pixel on screen happened
+// (a body moved a full pixel since the last requested redraw, the cursor
+// moved, a key was pressed, or the camera changed), signals drawFrame so the background scheduler knows to call
+// ebiten.ScheduleFrame(). Idle frames are otherwise free to skip rendering.
+func (g *Game) requestDrawIfNeeded(cursor_x, cursor_y int) {
+	redraw := false
+
+	if g.last_screen_pos == nil {
+		g.last_screen_pos = make(map[string]Vec2, len(g.bodies))
+	}
+	screen_pos := make(map[string]Vec2, len(g.bodies))
+	for name, cb := range g.bodies {
+		sp := cb.ScreenPosition(g.camera)
+		if prev, ok := g.last_screen_pos[name]; !ok || math.Abs(sp.X-prev.X) >= 1 || math.Abs(sp.Y-prev.Y) >= 1 {
+			redraw = true
+		}
+		screen_pos[name] = sp
+	}
+
+	if cursor_x != g.last_cursor_x || cursor_y != g.last_cursor_y {
+		redraw = true
+	}
+	g.last_cursor_x, g.last_cursor_y = cursor_x, cursor_y
+
+	pressed := inpututil.AppendPressedKeys(nil)
+	if !keySlicesEqual(pressed, g.last_pressed_keys) {
+		redraw = true
+	}
+	g.last_pressed_keys = pressed
+
+	if *g.camera != g.last_camera {
+		redraw = true
+	}
+	g.last_camera = *g.camera
+
+	if redraw {
+		g.last_screen_pos = screen_pos
+		g.dirty = true
+		select {
+		case g.drawFrame <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// keySlicesEqual reports whether a and b contain the same keys, ignoring order.
+func keySlicesEqual(a, b []ebiten.Key) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, k := range a {
+		found := false
+		for _, other := range b {
+			if k == other {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// runFrameScheduler rate-limits redraws to refresh_rate Hz: it only calls
+// ebiten.ScheduleFrame() on ticks where Update has actually signaled
+// drawFrame, so an idle system drops to near-zero CPU instead of redrawing
+// at 60 Hz forever. This only has an effect because main() puts ebiten in
+// FPSModeVsyncOffMinimum, where Ebiten itself stops calling Update/Draw
+// except in response to input or an explicit ScheduleFrame().
+//
+// If g.has_orbiters, every tick also forces a ScheduleFrame() regardless of
+// drawFrame. requestDrawIfNeeded only signals drawFrame once a body's
+// screen position has moved a full pixel since the last requested redraw,
+// so at a slow enough time_scale or a long enough orbit period a single
+// tick's motion stays under that threshold — and since Update itself is
+// only invoked in response to a ScheduleFrame in this FPS mode, waiting for
+// drawFrame alone would silently stop the simulation from ever advancing.
+// Forcing the frame here keeps Update running (and sub-pixel motion
+// accumulating toward the next real redraw) even when no single tick
+// crosses the threshold on its own; Draw's own dirty check still skips the
+// actual repaint on the ticks where nothing moved enough to matter.
+func (g *Game) runFrameScheduler() {
+	ticker := time.NewTicker(time.Second / time.Duration(g.refresh_rate))
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if g.has_orbiters {
+			ebiten.ScheduleFrame()
+			continue
+		}
+
+		select {
+		case <-g.drawFrame:
+			ebiten.ScheduleFrame()
+		default:
+		}
+	}
+}
+
+// PickAt returns the CelestialBody nearest to the screen-space point (cx, cy)
+// that actually contains it, or nil if none do. Each body is first rejected
+// cheaply against its screen-space AABB before falling back to a real
+// circular distance test.
+func (g *Game) PickAt(cx, cy int) *CelestialBody {
+	pickables := make([]Pickable, 0, len(g.bodies))
+	for _, cb := range g.bodies {
+		pickables = append(pickables, cb)
+	}
+
+	hit := pickAt(pickables, g.camera, cx, cy)
+	if hit == nil {
+		return nil
+	}
+
+	return hit.(*CelestialBody)
+}
+
+// pickAt returns whichever Pickable in candidates is nearest to the
+// screen-space point (cx, cy) and actually contains it, or nil if none do.
+// Each candidate is first rejected cheaply against its screen-space AABB
+// before falling back to a real circular distance test. This is written
+// against Pickable rather than *CelestialBody directly so any future
+// pickable type (e.g. a space station, a UI marker) can be hit-tested the
+// same way.
+func pickAt(candidates []Pickable, camera *Camera, cx, cy int) Pickable {
+	point := image.Pt(cx, cy)
+
+	var nearest Pickable
+	nearestDist := math.Inf(1)
+
+	for _, p := range candidates {
+		if !point.In(p.Bounds(camera)) {
+			continue
+		}
+
+		sp := p.ScreenPosition(camera)
+		dist_x, dist_y := sp.X-float64(cx), sp.Y-float64(cy)
+		dist := math.Sqrt(dist_x*dist_x + dist_y*dist_y)
+
+		if dist <= p.Radius(camera) && dist < nearestDist {
+			nearest = p
+			nearestDist = dist
+		}
+	}
+
+	return nearest
+}
+
+// TrueAnomalyToPosition computes the 3D position of a body along its orbit
+// for the given true anomaly theta. It first finds the perifocal-frame
+// coordinates (r*cos theta, r*sin theta, 0), then rotates them into an
+// ECI-like frame via R = Rz(lan) * Rx(inclination) * Rz(argument_of_periapsis)
+// so inclined, rotated orbits come out correctly. inclination,
+// argument_of_periapsis and longitude_of_ascending_node are in degrees,
+// matching how they're specified in system files; theta is in radians.
+func TrueAnomalyToPosition(a, e, inclination, argument_of_periapsis, longitude_of_ascending_node, theta float64) (x, y, z float64) {
 	// Calculate the radial distance for the current angle (true anomaly)
 	r := a * (1 - e*e) / (1 + e*math.Cos(theta))
 
-	// Position in the orbit (without inclination)
-	x := r * math.Cos(theta)
-	y := r * math.Sin(theta)
+	// Perifocal-frame position (before any rotation)
+	xp := r * math.Cos(theta)
+	yp := r * math.Sin(theta)
+
+	incl := inclination * math.Pi / 180
+	lan := longitude_of_ascending_node * math.Pi / 180
+	argp := argument_of_periapsis * math.Pi / 180
+
+	cosLan, sinLan := math.Cos(lan), math.Sin(lan)
+	cosIncl, sinIncl := math.Cos(incl), math.Sin(incl)
+	cosArgp, sinArgp := math.Cos(argp), math.Sin(argp)
+
+	x = (cosLan*cosArgp-sinLan*sinArgp*cosIncl)*xp + (-cosLan*sinArgp-sinLan*cosArgp*cosIncl)*yp
+	y = (sinLan*cosArgp+cosLan*sinArgp*cosIncl)*xp + (-sinLan*sinArgp+cosLan*cosArgp*cosIncl)*yp
+	z = (sinArgp*sinIncl)*xp + (cosArgp*sinIncl)*yp
+
+	return x, y, z
+}
 
-	return x, y
+// ProjectToScreenPlane flattens a 3D orbital position to 2D for drawing,
+// tilting the whole system by view_pitch (an isometric-style tilt; at
+// view_pitch == 0 this is a plain orthographic (X, Y) drop).
+func ProjectToScreenPlane(x, y, z, view_pitch float64) (float64, float64) {
+	return x, y*math.Cos(view_pitch) - z*math.Sin(view_pitch)
 }
 
-func (o *Orbit) Draw(cx, cy float64, screen *ebiten.Image) {
+func (o *Orbit) Draw(origin Vec2, camera *Camera, screen *ebiten.Image) {
 	// Semi-major axis
 	a := (o.apoapsis + o.periapsis) / 2
 	// Eccentricity is pre-set
@@ -108,134 +379,402 @@ func (o *Orbit) Draw(cx, cy float64, screen *ebiten.Image) {
 		t1 := float64(i) / float64(numSteps) * 2 * math.Pi
 		t2 := float64(i+1) / float64(numSteps) * 2 * math.Pi
 
-		// Get positions for the two points on the ellipse
-		x1, y1 := TrueAnomalyToPosition(a, e, o.inclination, t1)
-		x2, y2 := TrueAnomalyToPosition(a, e, o.inclination, t2)
+		// Get positions for the two points on the ellipse, projected the
+		// same way the body itself is, so a tilted orbit draws as a tilted
+		// ellipse rather than a flat one.
+		x1, y1, z1 := TrueAnomalyToPosition(a, e, o.inclination, o.argument_of_periapsis, o.longitude_of_ascending_node, t1)
+		x2, y2, z2 := TrueAnomalyToPosition(a, e, o.inclination, o.argument_of_periapsis, o.longitude_of_ascending_node, t2)
+		px1, py1 := ProjectToScreenPlane(x1, y1, z1, camera.ViewPitch)
+		px2, py2 := ProjectToScreenPlane(x2, y2, z2, camera.ViewPitch)
+
+		sx1, sy1 := camera.WorldToScreen(Vec2{X: origin.X + px1, Y: origin.Y + py1})
+		sx2, sy2 := camera.WorldToScreen(Vec2{X: origin.X + px2, Y: origin.Y + py2})
 
 		// Draw the orbit path
-		vector.StrokeLine(screen, float32(cx+x1), float32(cy+y1), float32(cx+x2), float32(cy+y2), 1, color.White, false)
+		vector.StrokeLine(screen, sx1, sy1, sx2, sy2, 1, color.White, false)
 	}
 }
 
-func (cb *CelestialBody) GetPosition() (x, y float64) {
+// GetPosition returns cb's world-space position, i.e. where its parent chain
+// places it, not including its own offset along its own orbit. The root of a
+// system sits at the world origin; Camera.WorldToScreen maps world space to
+// screen pixels.
+func (cb *CelestialBody) GetPosition(view_pitch float64) (x, y float64) {
 	if cb.parent == nil {
-		return screenWidth / 2, screenHeight / 2 // Center of the screen
+		return 0, 0 // World origin
+	}
+
+	px, py := cb.parent.GetPosition(view_pitch)
+	if cb.parent.orbit == nil {
+		// A root body with no orbit of its own (e.g. a lone star) sits
+		// exactly where it is, so there's no extra offset to add.
+		return px, py
 	}
 
+	o := cb.parent.orbit
 	theta := cb.parent.position_on_orbit * 2 * math.Pi
-	a := (cb.parent.orbit.apoapsis + cb.parent.orbit.periapsis) / 2
-	e := (a - cb.parent.orbit.periapsis) / a
-	x, y = TrueAnomalyToPosition(a, e, cb.parent.orbit.inclination, theta)
+	a := (o.apoapsis + o.periapsis) / 2
+	e := (a - o.periapsis) / a
+	wx, wy, wz := TrueAnomalyToPosition(a, e, o.inclination, o.argument_of_periapsis, o.longitude_of_ascending_node, theta)
+	x, y = ProjectToScreenPlane(wx, wy, wz, view_pitch)
 
-	px, py := cb.parent.GetPosition()
 	return x + px, y + py
 }
 
-func (cb *CelestialBody) Update() {
-	// Semi-major axis
-	a := (cb.orbit.apoapsis + cb.orbit.periapsis) / 2
+// Update advances the body along its orbit by solving Kepler's equation for
+// the elapsed wall time since the last call. now is a UnixNano timestamp and
+// time_scale lets the caller speed up or slow down the simulation.
+func (cb *CelestialBody) Update(now int64, time_scale float64) {
+	if cb.orbit == nil {
+		return
+	}
 
-	// Calculate the current true anomaly based on position in the orbit
-	theta := cb.position_on_orbit * 2 * math.Pi
+	a := (cb.orbit.apoapsis + cb.orbit.periapsis) / 2
 	e := (a - cb.orbit.periapsis) / a
-	r := a * (1 - e*e) / (1 + e*math.Cos(theta))
-	v := math.Sqrt(cb.mass * math.Pow(cb.gravity, 2) / 60 * (2/r - 1/a))
 
-	// this is not real, magic trick to emulate the behaviour of kepler's second law
-	cb.position_on_orbit += v * v / 100
+	if cb.last_update_time == 0 {
+		// First tick: seed mean_anomaly from the configured position_on_orbit
+		// (the config's starting true anomaly) instead of leaving it at its
+		// zero value, then establish a baseline without advancing yet.
+		// Without this every shipped system snaps to its periapsis-seeded
+		// trajectory on the very next tick.
+		theta0 := cb.position_on_orbit * 2 * math.Pi
+		E0 := math.Atan2(math.Sqrt(1-e*e)*math.Sin(theta0), e+math.Cos(theta0))
+		cb.mean_anomaly = E0 - e*math.Sin(E0)
+		cb.last_update_time = now
+		return
+	}
+
+	dt := float64(now-cb.last_update_time) / float64(time.Second) * time_scale
+	cb.last_update_time = now
+
+	cb.mean_anomaly += 2 * math.Pi * dt / cb.orbit.period
+	cb.mean_anomaly = math.Mod(cb.mean_anomaly, 2*math.Pi)
+	if cb.mean_anomaly < 0 {
+		cb.mean_anomaly += 2 * math.Pi
+	}
+
+	E := SolveKeplerEquation(cb.mean_anomaly, e)
+	theta := math.Atan2(math.Sqrt(1-e*e)*math.Sin(E), math.Cos(E)-e)
+	if theta < 0 {
+		theta += 2 * math.Pi
+	}
+
+	// position_on_orbit is kept as a derived fraction of a full revolution
+	// (true anomaly / 2π) so GetPosition, WorldPosition and the rest of the
+	// codebase don't need to know about mean/eccentric anomaly.
+	cb.position_on_orbit = theta / (2 * math.Pi)
+}
 
-	if cb.position_on_orbit >= 1 {
-		cb.position_on_orbit = 0
+// SolveKeplerEquation solves M = E - e*sin(E) for the eccentric anomaly E via
+// Newton-Raphson, starting from E0 = M (or M + e*sin(M) for better
+// convergence at high eccentricity).
+func SolveKeplerEquation(M, e float64) float64 {
+	E := M
+	if e > 0.8 {
+		E = M + e*math.Sin(M)
 	}
 
+	for i := 0; i < 20; i++ {
+		delta := (E - e*math.Sin(E) - M) / (1 - e*math.Cos(E))
+		E -= delta
+		if math.Abs(delta) < 1e-10 {
+			break
+		}
+	}
+
+	return E
 }
 
 func (cb *CelestialBodyDetails) Draw(screen *ebiten.Image) {
 	ebitenutil.DebugPrint(screen, "drawing details")
 }
 
-func (cb *CelestialBody) Draw(screen *ebiten.Image) {
-	// Semi-major axis
-	a := (cb.orbit.apoapsis + cb.orbit.periapsis) / 2
+// WorldPosition returns the body's actual world-space position: its parent
+// chain's position plus the offset along its own orbit.
+func (cb *CelestialBody) WorldPosition(view_pitch float64) Vec2 {
+	ax, ay := cb.GetPosition(view_pitch)
+
+	if cb.orbit == nil {
+		return Vec2{X: ax, Y: ay}
+	}
 
-	// Calculate the current true anomaly based on position in the orbit
+	o := cb.orbit
+	a := (o.apoapsis + o.periapsis) / 2
 	theta := cb.position_on_orbit * 2 * math.Pi
-	e := (a - cb.orbit.periapsis) / a
+	e := (a - o.periapsis) / a
+	wx, wy, wz := TrueAnomalyToPosition(a, e, o.inclination, o.argument_of_periapsis, o.longitude_of_ascending_node, theta)
+	x, y := ProjectToScreenPlane(wx, wy, wz, view_pitch)
+
+	return Vec2{X: ax + x, Y: ay + y}
+}
+
+// ScreenPosition returns where cb is actually drawn on screen, for the given
+// camera.
+func (cb *CelestialBody) ScreenPosition(camera *Camera) Vec2 {
+	sx, sy := camera.WorldToScreen(cb.WorldPosition(camera.ViewPitch))
+	return Vec2{X: float64(sx), Y: float64(sy)}
+}
+
+// Radius returns the body's drawing/picking radius in screen pixels, scaled
+// by the camera's zoom so moons stay visible when zoomed out on the sun.
+func (cb *CelestialBody) Radius(camera *Camera) float64 {
+	r := cb.radius
+	if r == 0 {
+		r = 5
+	}
+
+	r *= camera.Zoom
+	return math.Max(r, 2)
+}
 
-	x, y := TrueAnomalyToPosition(a, e, cb.orbit.inclination, theta)
-	ax, ay := cb.GetPosition()
+// Bounds returns cb's screen-space bounding box, used as a cheap AABB reject
+// before a real circular hit test in Game.PickAt.
+func (cb *CelestialBody) Bounds(camera *Camera) image.Rectangle {
+	sp := cb.ScreenPosition(camera)
+	r := int(cb.Radius(camera))
+	return image.Rect(int(sp.X)-r, int(sp.Y)-r, int(sp.X)+r, int(sp.Y)+r)
+}
 
-	cb.orbit.Draw(ax, ay, screen)
+func (cb *CelestialBody) Draw(screen *ebiten.Image, camera *Camera) {
+	if cb.orbit != nil {
+		ox, oy := cb.GetPosition(camera.ViewPitch)
+		cb.orbit.Draw(Vec2{X: ox, Y: oy}, camera, screen)
+	}
 
-	vector.DrawFilledCircle(screen, float32(float64(ax)+x), float32(float64(ay)+y), 5, color.RGBA{255, 0, 0, 255}, false)
+	sp := cb.ScreenPosition(camera)
+	vector.DrawFilledCircle(screen, float32(sp.X), float32(sp.Y), float32(cb.Radius(camera)), cb.color, false)
 }
 
 func (g *Game) DrawFocalPoint(screen *ebiten.Image) {
-	cx, cy := screenWidth/2, screenHeight/2 // Center of the screen
+	cx, cy := g.camera.WorldToScreen(Vec2{})
 
-	vector.DrawFilledCircle(screen, float32(float64(cx)), float32(float64(cy)), 15, color.RGBA{0, 255, 0, 255}, false)
+	vector.DrawFilledCircle(screen, cx, cy, 15, color.RGBA{0, 255, 0, 255}, false)
 }
 
-// Draw draws the game screen.
+// Draw draws the game screen. With ebiten.SetScreenClearedEveryFrame(false)
+// and FPSModeVsyncOffMinimum, Draw is only invoked when input was detected
+// or ScheduleFrame was called, and many of those invocations (e.g. the
+// ScheduleFrame that runFrameScheduler forces every tick while bodies orbit)
+// arrive when requestDrawIfNeeded saw nothing change, so g.dirty is still
+// false; skip the repaint entirely in that case so idle frames do no GPU
+// work at all.
 func (g *Game) Draw(screen *ebiten.Image) {
+	if !g.dirty {
+		return
+	}
+	g.dirty = false
+
 	screen.Fill(color.Black)
 
+	// Sort for a stable draw order; map iteration order is randomized in Go.
+	names := make([]string, 0, len(g.bodies))
+	for name := range g.bodies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		g.bodies[name].Draw(screen, g.camera)
+	}
+
+	g.drawHoverRing(screen)
+
 	if g.focused_body != nil {
 		g.focused_body.details.Draw(screen)
-	} else {
-		g.sun.Draw(screen)
-		g.earth.Draw(screen)
-		g.moon.Draw(screen)
 	}
 }
 
+// drawHoverRing highlights the currently hovered body with a ring around it.
+func (g *Game) drawHoverRing(screen *ebiten.Image) {
+	if g.hovered_body == nil {
+		return
+	}
+
+	sp := g.hovered_body.ScreenPosition(g.camera)
+	vector.StrokeCircle(screen, float32(sp.X), float32(sp.Y), float32(g.hovered_body.Radius(g.camera))+3, 2, color.RGBA{255, 255, 0, 255}, false)
+}
+
 // Layout takes the outside size (e.g., the window size) and returns the (logical) screen size.
 // If you don't have to adjust the screen size with the outside size, just return a fixed size.
 func (g *Game) Layout(outsideWidth, outsideHeight int) (_, _ int) {
 	return screenWidth, screenHeight
 }
 
+// OrbitConfig is the on-disk description of an Orbit, as loaded from a
+// systems/*.json or systems/*.yaml file.
+type OrbitConfig struct {
+	Apoapsis                 float64 `json:"apoapsis" yaml:"apoapsis"`
+	Periapsis                float64 `json:"periapsis" yaml:"periapsis"`
+	Inclination              float64 `json:"inclination" yaml:"inclination"`
+	Period                   float64 `json:"period" yaml:"period"`
+	ArgumentOfPeriapsis      float64 `json:"argument_of_periapsis" yaml:"argument_of_periapsis"`
+	LongitudeOfAscendingNode float64 `json:"longitude_of_ascending_node" yaml:"longitude_of_ascending_node"`
+}
+
+// BodyConfig is the on-disk description of a single CelestialBody. Parent is
+// the name of another body in the same file, or empty for a root body.
+type BodyConfig struct {
+	Name            string       `json:"name" yaml:"name"`
+	Parent          string       `json:"parent,omitempty" yaml:"parent,omitempty"`
+	Mass            float64      `json:"mass" yaml:"mass"`
+	Gravity         float64      `json:"gravity" yaml:"gravity"`
+	Radius          float64      `json:"radius" yaml:"radius"`
+	Color           string       `json:"color,omitempty" yaml:"color,omitempty"`
+	Orbit           *OrbitConfig `json:"orbit,omitempty" yaml:"orbit,omitempty"`
+	PositionOnOrbit float64      `json:"position_on_orbit,omitempty" yaml:"position_on_orbit,omitempty"`
+}
+
+// SystemConfig is the top-level shape of a systems/*.json or *.yaml file.
+type SystemConfig struct {
+	Name   string       `json:"name" yaml:"name"`
+	Bodies []BodyConfig `json:"bodies" yaml:"bodies"`
+}
+
+// LoadSystem reads a system description from path (JSON or YAML, chosen by
+// file extension) and builds the tree of CelestialBody it describes.
+func LoadSystem(path string) (map[string]*CelestialBody, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading system file: %w", err)
+	}
+
+	var cfg SystemConfig
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			return nil, fmt.Errorf("parsing system file as yaml: %w", err)
+		}
+	default:
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return nil, fmt.Errorf("parsing system file as json: %w", err)
+		}
+	}
+
+	bodies := make(map[string]*CelestialBody, len(cfg.Bodies))
+	for _, bc := range cfg.Bodies {
+		if bc.Name == "" {
+			return nil, fmt.Errorf("body with empty name in %s", path)
+		}
+		if _, exists := bodies[bc.Name]; exists {
+			return nil, fmt.Errorf("duplicate body name %q in %s", bc.Name, path)
+		}
+
+		cb := &CelestialBody{
+			name:    bc.Name,
+			mass:    bc.Mass,
+			gravity: bc.Gravity,
+			radius:  bc.Radius,
+			color:   parseHexColor(bc.Color),
+		}
+
+		if bc.Orbit != nil {
+			cb.orbit = &Orbit{
+				apoapsis:                    bc.Orbit.Apoapsis,
+				periapsis:                   bc.Orbit.Periapsis,
+				inclination:                 bc.Orbit.Inclination,
+				period:                      bc.Orbit.Period,
+				argument_of_periapsis:       bc.Orbit.ArgumentOfPeriapsis,
+				longitude_of_ascending_node: bc.Orbit.LongitudeOfAscendingNode,
+			}
+			cb.position_on_orbit = bc.PositionOnOrbit
+		}
+
+		bodies[bc.Name] = cb
+	}
+
+	for _, bc := range cfg.Bodies {
+		if bc.Parent == "" {
+			continue
+		}
+
+		parent, ok := bodies[bc.Parent]
+		if !ok {
+			return nil, fmt.Errorf("body %q references unknown parent %q", bc.Name, bc.Parent)
+		}
+
+		bodies[bc.Name].parent = parent
+	}
+
+	for _, bc := range cfg.Bodies {
+		if err := checkParentCycle(bodies[bc.Name]); err != nil {
+			return nil, fmt.Errorf("in %s: %w", path, err)
+		}
+	}
+
+	return bodies, nil
+}
+
+// checkParentCycle walks cb's parent chain and returns an error if it loops
+// back on itself instead of terminating at a root. Without this, a cyclic
+// system file would recurse forever in GetPosition/WorldPosition the first
+// time the system is drawn.
+func checkParentCycle(cb *CelestialBody) error {
+	seen := make(map[*CelestialBody]bool)
+	for cur := cb; cur != nil; cur = cur.parent {
+		if seen[cur] {
+			return fmt.Errorf("body %q has a cyclic parent chain", cb.name)
+		}
+		seen[cur] = true
+	}
+
+	return nil
+}
+
+// parseHexColor parses a "#rrggbb" string into an opaque color.RGBA, falling
+// back to white if s is empty or malformed.
+func parseHexColor(s string) color.RGBA {
+	s = strings.TrimPrefix(s, "#")
+	if len(s) != 6 {
+		return color.RGBA{255, 255, 255, 255}
+	}
+
+	r, err1 := strconv.ParseUint(s[0:2], 16, 8)
+	g, err2 := strconv.ParseUint(s[2:4], 16, 8)
+	b, err3 := strconv.ParseUint(s[4:6], 16, 8)
+	if err1 != nil || err2 != nil || err3 != nil {
+		return color.RGBA{255, 255, 255, 255}
+	}
+
+	return color.RGBA{uint8(r), uint8(g), uint8(b), 255}
+}
+
 func main() {
-	game := &Game{}
-	game.sun = CelestialBody{
-		mass:    5.9,
-		gravity: 8,
-		orbit: &Orbit{
-			inclination: 0,   // Inclination (tilt of the orbit)
-			apoapsis:    300, // Apoapsis (furthest point)
-			periapsis:   100, // Periapsis (closest point)
-			period:      1.0, // Orbital period
-		},
-		position_on_orbit: 0.25, // Starting position on orbit
-	}
-
-	game.earth = CelestialBody{
-		parent:  &game.sun,
-		mass:    .9,
-		gravity: 8,
-		orbit: &Orbit{
-			inclination: 0,   // Inclination (tilt of the orbit)
-			apoapsis:    30,  // Apoapsis (furthest point)
-			periapsis:   20,  // Periapsis (closest point)
-			period:      1.0, // Orbital period
-		},
-		position_on_orbit: 0.25, // Starting position on orbit
-	}
-
-	// ignore that the moon shares orbit with he earth around the sun
-	// also ignore that the sun rotates around a center point
-	// this is not really very intuitive...
-	game.moon = CelestialBody{
-		parent:            &game.sun,
-		mass:              .9,
-		gravity:           3,
-		orbit:             game.earth.orbit,
-		position_on_orbit: .75,
+	system := flag.String("system", "systems/sol.json", "path to the system description file to load")
+	flag.Parse()
+
+	bodies, err := LoadSystem(*system)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	has_orbiters := false
+	for _, cb := range bodies {
+		if cb.orbit != nil {
+			has_orbiters = true
+			break
+		}
+	}
+
+	game := &Game{
+		bodies:       bodies,
+		camera:       NewCamera(),
+		time_scale:   1.0,
+		refresh_rate: 60,
+		drawFrame:    make(chan struct{}, 1),
+		dirty:        true, // always paint the first frame
+		has_orbiters: has_orbiters,
 	}
+	go game.runFrameScheduler()
 
 	// Specify the window size as you like. Here, a doubled size is specified.
 	ebiten.SetWindowSize(renderWidth, renderHeight)
 	ebiten.SetWindowTitle("gospace")
+	ebiten.SetScreenClearedEveryFrame(false)
+	// ScheduleFrame() is a no-op outside FPSModeVsyncOffMinimum: only in this
+	// mode does Ebiten skip Update/Draw on idle frames instead of calling
+	// them at a fixed vsync rate regardless of whether anything changed.
+	ebiten.SetFPSMode(ebiten.FPSModeVsyncOffMinimum)
 
 	if err := ebiten.RunGame(game); err != nil {
 		log.Fatal(err)